@@ -0,0 +1,100 @@
+// Package config loads Jay's configuration file, choosing a JSON or YAML
+// decoder based on the file extension so env.json and env.yaml are both
+// supported everywhere Jay reads configuration.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blue-jay-fork/core/jsonconfig"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Load reads the config file at path into v, using YAML when the file has
+// a .yaml or .yml extension and JSON otherwise.
+func Load(path string, v interface{}) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return yaml.Unmarshal(b, v)
+	default:
+		return jsonconfig.Load(path, v)
+	}
+}
+
+// LoadFromEnv reads the config file referenced by the JAYCONFIG
+// environment variable into v.
+func LoadFromEnv(v interface{}) error {
+	path := os.Getenv("JAYCONFIG")
+	if len(path) == 0 {
+		return fmt.Errorf("JAYCONFIG environment variable is not set")
+	}
+	return Load(path, v)
+}
+
+// ConvertToYAML reads the JSON config file at jsonPath and writes an
+// equivalent env.yaml to yamlPath.
+//
+// Numbers are decoded with json.Number rather than taking encoding/json's
+// default of unmarshaling every number into float64, then resolved back
+// to int64 or float64 before being re-marshaled, so a large integer like
+// a session key byte count doesn't pick up float64 rounding on a
+// round trip through this conversion.
+func ConvertToYAML(jsonPath, yamlPath string) error {
+	b, err := os.ReadFile(jsonPath)
+	if err != nil {
+		return err
+	}
+
+	raw := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+
+	out, err := yaml.Marshal(resolveNumbers(raw))
+	if err != nil {
+		return err
+	}
+
+	header := "# Converted from " + filepath.Base(jsonPath) + " by `jay env convert --to yaml`.\n"
+	return os.WriteFile(yamlPath, append([]byte(header), out...), 0644)
+}
+
+// resolveNumbers walks a value decoded with json.Number support,
+// replacing each json.Number with an int64 when it parses as one and a
+// float64 otherwise, so yaml.Marshal emits a bare number instead of a
+// quoted string.
+func resolveNumbers(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			val[k] = resolveNumbers(item)
+		}
+		return val
+	case []interface{}:
+		for i, item := range val {
+			val[i] = resolveNumbers(item)
+		}
+		return val
+	case json.Number:
+		if n, err := val.Int64(); err == nil {
+			return n
+		}
+		if f, err := val.Float64(); err == nil {
+			return f
+		}
+		return val.String()
+	default:
+		return val
+	}
+}
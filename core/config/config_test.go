@@ -0,0 +1,84 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+type testConfig struct {
+	MySQL struct {
+		Hostname string `yaml:"Hostname"`
+	} `yaml:"MySQL"`
+}
+
+func TestLoadYAMLExtensions(t *testing.T) {
+	contents := []byte("MySQL:\n  Hostname: localhost\n")
+
+	for _, ext := range []string{".yaml", ".yml", ".YAML"} {
+		t.Run(ext, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "env"+ext)
+			if err := os.WriteFile(path, contents, 0644); err != nil {
+				t.Fatalf("os.WriteFile() = %v", err)
+			}
+
+			var cfg testConfig
+			if err := Load(path, &cfg); err != nil {
+				t.Fatalf("Load(%q) = %v", path, err)
+			}
+
+			if cfg.MySQL.Hostname != "localhost" {
+				t.Errorf("Load(%q) MySQL.Hostname = %q, want %q", path, cfg.MySQL.Hostname, "localhost")
+			}
+		})
+	}
+}
+
+func TestLoadFromEnvRequiresJAYCONFIG(t *testing.T) {
+	t.Setenv("JAYCONFIG", "")
+
+	var cfg testConfig
+	if err := LoadFromEnv(&cfg); err == nil {
+		t.Fatal("LoadFromEnv() with unset JAYCONFIG = nil, want error")
+	}
+}
+
+func TestConvertToYAMLPreservesLargeNumbers(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "env.json")
+	yamlPath := filepath.Join(dir, "env.yaml")
+
+	// 9007199254740993 is 2^53+1, the smallest integer a float64 can't
+	// represent exactly, so decoding it into float64 (encoding/json's
+	// default for numbers) would round it to 9007199254740992.
+	contents := []byte(`{"LargeNumber": 9007199254740993, "Pi": 3.14}`)
+	if err := os.WriteFile(jsonPath, contents, 0644); err != nil {
+		t.Fatalf("os.WriteFile() = %v", err)
+	}
+
+	if err := ConvertToYAML(jsonPath, yamlPath); err != nil {
+		t.Fatalf("ConvertToYAML() = %v", err)
+	}
+
+	out, err := os.ReadFile(yamlPath)
+	if err != nil {
+		t.Fatalf("os.ReadFile() = %v", err)
+	}
+
+	var got struct {
+		LargeNumber int64   `yaml:"LargeNumber"`
+		Pi          float64 `yaml:"Pi"`
+	}
+	if err := yaml.Unmarshal(out, &got); err != nil {
+		t.Fatalf("yaml.Unmarshal(%q) = %v", out, err)
+	}
+
+	if got.LargeNumber != 9007199254740993 {
+		t.Errorf("LargeNumber = %v, want %v", got.LargeNumber, 9007199254740993)
+	}
+	if got.Pi != 3.14 {
+		t.Errorf("Pi = %v, want %v", got.Pi, 3.14)
+	}
+}
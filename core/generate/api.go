@@ -0,0 +1,100 @@
+package generate
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Column describes a single column of an introspected table.
+type Column struct {
+	Name     string
+	DataType string
+}
+
+// Table describes an introspected table and its columns.
+type Table struct {
+	Name    string
+	Columns []Column
+}
+
+// API introspects the database reachable at conn using driver, generates a
+// model, a CRUD controller, and route wiring for every matching table via
+// the "api/model", "api/controller", and "api/routes" template pairs, and
+// writes them into projectFolder. When tables is empty, every table found
+// is generated.
+func API(driver, conn string, tables []string, projectFolder, templateFolder string) error {
+	db, err := sql.Open(driver, conn)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	found, err := readTables(db, driver, tables)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range found {
+		vars := []string{fmt.Sprintf("table:%v", t.Name)}
+
+		if err := Run(append([]string{"api/model"}, vars...), projectFolder, templateFolder); err != nil {
+			return err
+		}
+		if err := Run(append([]string{"api/controller"}, vars...), projectFolder, templateFolder); err != nil {
+			return err
+		}
+		if err := Run(append([]string{"api/routes"}, vars...), projectFolder, templateFolder); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readTables queries the database's schema catalog for the requested
+// tables (or every table when want is empty) along with their columns.
+func readTables(db *sql.DB, driver string, want []string) ([]Table, error) {
+	query := "SELECT table_name, column_name, data_type FROM information_schema.columns WHERE table_schema = database() ORDER BY table_name, ordinal_position"
+	if driver == "postgres" {
+		query = "SELECT table_name, column_name, data_type FROM information_schema.columns WHERE table_schema = 'public' ORDER BY table_name, ordinal_position"
+	} else if driver == "sqlite3" {
+		query = "SELECT m.name AS table_name, p.name AS column_name, p.type AS data_type FROM sqlite_master m JOIN pragma_table_info(m.name) p WHERE m.type = 'table' ORDER BY m.name, p.cid"
+	}
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	filter := map[string]bool{}
+	for _, name := range want {
+		filter[strings.TrimSpace(name)] = true
+	}
+
+	index := map[string]int{}
+	var tables []Table
+
+	for rows.Next() {
+		var tableName, columnName, dataType string
+		if err := rows.Scan(&tableName, &columnName, &dataType); err != nil {
+			return nil, err
+		}
+
+		if len(filter) > 0 && !filter[tableName] {
+			continue
+		}
+
+		i, ok := index[tableName]
+		if !ok {
+			tables = append(tables, Table{Name: tableName})
+			i = len(tables) - 1
+			index[tableName] = i
+		}
+
+		tables[i].Columns = append(tables[i].Columns, Column{Name: columnName, DataType: dataType})
+	}
+
+	return tables, rows.Err()
+}
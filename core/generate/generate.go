@@ -0,0 +1,85 @@
+// Package generate runs template pairs to scaffold files into a Blue Jay
+// project.
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// Container holds the generation settings read from the config file.
+//
+// Without the yaml tags below, yaml.v2 would look for a lowercase
+// "generation" key instead of the "Generation" key env.json (and the
+// env.yaml derived from it) actually uses, and TemplateFolder would come
+// back empty instead of erroring, which is a much harder bug to spot.
+type Container struct {
+	Generation Generation `yaml:"Generation"`
+}
+
+// Generation holds the settings needed to locate and run template pairs.
+type Generation struct {
+	TemplateFolder string `yaml:"TemplateFolder"`
+}
+
+// Run executes the template pair named by args[0] against the project. A
+// template pair is two files in templateFolder: "<name>.path.tmpl", which
+// renders to the output file's path relative to projectFolder, and
+// "<name>.body.tmpl", which renders to its contents. Any remaining args
+// are "key:value" strings made available to both templates by key, e.g.
+// "table:users" lets a template reference {{.table}}.
+func Run(args []string, projectFolder, templateFolder string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("generate: missing template pair name")
+	}
+
+	name := args[0]
+	vars := parseVars(args[1:])
+
+	pathTmpl, err := template.ParseFiles(filepath.Join(templateFolder, name+".path.tmpl"))
+	if err != nil {
+		return err
+	}
+
+	bodyTmpl, err := template.ParseFiles(filepath.Join(templateFolder, name+".body.tmpl"))
+	if err != nil {
+		return err
+	}
+
+	var pathOut, bodyOut bytes.Buffer
+
+	if err := pathTmpl.Execute(&pathOut, vars); err != nil {
+		return err
+	}
+	if err := bodyTmpl.Execute(&bodyOut, vars); err != nil {
+		return err
+	}
+
+	outputPath := filepath.Join(projectFolder, strings.TrimSpace(pathOut.String()))
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(outputPath, bodyOut.Bytes(), 0644)
+}
+
+// parseVars splits "key:value" args into a map usable as template data.
+// Args without a colon are skipped.
+func parseVars(args []string) map[string]string {
+	vars := map[string]string{}
+
+	for _, a := range args {
+		parts := strings.SplitN(a, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		vars[parts[0]] = parts[1]
+	}
+
+	return vars
+}
@@ -0,0 +1,146 @@
+// Package log provides the small leveled logger Jay uses for every command
+// so its output can be consumed as plain text or as line-delimited JSON.
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Level is a logging severity, ordered from most to least verbose.
+type Level int
+
+// Severity levels supported by --log-level.
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+	LevelFatal
+)
+
+// levelName is the lowercase name used in output and in the --log-level
+// flag, indexed by Level.
+var levelName = [...]string{"debug", "info", "warn", "error", "fatal"}
+
+// String returns the lowercase name of the level.
+func (l Level) String() string {
+	if l < LevelDebug || l > LevelFatal {
+		return "info"
+	}
+	return levelName[l]
+}
+
+// ParseLevel converts a --log-level flag value into a Level, defaulting to
+// LevelInfo when name is empty or unrecognized.
+func ParseLevel(name string) Level {
+	for l, n := range levelName {
+		if n == strings.ToLower(name) {
+			return Level(l)
+		}
+	}
+	return LevelInfo
+}
+
+// Format selects how events are rendered.
+type Format int
+
+// Output formats supported by --log-format.
+const (
+	FormatText Format = iota
+	FormatJSON
+)
+
+// ParseFormat converts a --log-format flag value into a Format, defaulting
+// to FormatText for anything other than "json".
+func ParseFormat(name string) Format {
+	if strings.ToLower(name) == "json" {
+		return FormatJSON
+	}
+	return FormatText
+}
+
+// Fields carries the extra key/value pairs attached to a single event.
+type Fields map[string]interface{}
+
+// Logger writes leveled events to stdout (stderr for warn and above) as
+// plain text or as one JSON object per line.
+type Logger struct {
+	cmd    string
+	level  Level
+	format Format
+}
+
+// New creates a Logger scoped to cmd, the command being run, that only
+// emits events at or above level, rendered in the given format.
+func New(cmd string, level Level, format Format) *Logger {
+	return &Logger{cmd: cmd, level: level, format: format}
+}
+
+// Debug logs a debug-level event.
+func (l *Logger) Debug(event string, fields Fields) {
+	l.log(LevelDebug, event, fields)
+}
+
+// Info logs an info-level event.
+func (l *Logger) Info(event string, fields Fields) {
+	l.log(LevelInfo, event, fields)
+}
+
+// Warn logs a warn-level event.
+func (l *Logger) Warn(event string, fields Fields) {
+	l.log(LevelWarn, event, fields)
+}
+
+// Error logs an error-level event.
+func (l *Logger) Error(event string, fields Fields) {
+	l.log(LevelError, event, fields)
+}
+
+// Fatal logs a fatal-level event and then exits the process with status 1.
+func (l *Logger) Fatal(event string, fields Fields) {
+	l.log(LevelFatal, event, fields)
+	os.Exit(1)
+}
+
+// log renders a single event, dropping it if it is below the logger's
+// configured level.
+func (l *Logger) log(level Level, event string, fields Fields) {
+	if level < l.level {
+		return
+	}
+
+	out := os.Stdout
+	if level >= LevelError {
+		out = os.Stderr
+	}
+
+	if l.format == FormatJSON {
+		obj := map[string]interface{}{
+			"level": level.String(),
+			"cmd":   l.cmd,
+			"event": event,
+		}
+		for k, v := range fields {
+			obj[k] = v
+		}
+
+		b, err := json.Marshal(obj)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+
+		fmt.Fprintln(out, string(b))
+		return
+	}
+
+	line := fmt.Sprintf("[%v] %v: %v", strings.ToUpper(level.String()), l.cmd, event)
+	for k, v := range fields {
+		line += fmt.Sprintf(" %v=%v", k, v)
+	}
+
+	fmt.Fprintln(out, line)
+}
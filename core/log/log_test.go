@@ -0,0 +1,47 @@
+package log
+
+import "testing"
+
+func TestParseLevel(t *testing.T) {
+	cases := []struct {
+		name string
+		want Level
+	}{
+		{name: "debug", want: LevelDebug},
+		{name: "INFO", want: LevelInfo},
+		{name: "Warn", want: LevelWarn},
+		{name: "error", want: LevelError},
+		{name: "fatal", want: LevelFatal},
+		{name: "", want: LevelInfo},
+		{name: "bogus", want: LevelInfo},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := ParseLevel(c.name); got != c.want {
+				t.Errorf("ParseLevel(%q) = %v, want %v", c.name, got, c.want)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	cases := []struct {
+		name string
+		want Format
+	}{
+		{name: "json", want: FormatJSON},
+		{name: "JSON", want: FormatJSON},
+		{name: "text", want: FormatText},
+		{name: "", want: FormatText},
+		{name: "bogus", want: FormatText},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := ParseFormat(c.name); got != c.want {
+				t.Errorf("ParseFormat(%q) = %v, want %v", c.name, got, c.want)
+			}
+		})
+	}
+}
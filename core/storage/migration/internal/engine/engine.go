@@ -0,0 +1,361 @@
+// Package engine holds the migration file and tracking-table logic shared
+// by every database-specific migration driver (mysql, postgres, sqlite).
+// Only the DSN construction and the tracking table's bind-variable
+// placeholder syntax differ between drivers, so those are the only things
+// each driver package supplies; everything else lives here once so a fix
+// doesn't need to be made in three places in lockstep.
+package engine
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// UpSuffix is the extension appended to every "up" migration file.
+const UpSuffix = ".up.sql"
+
+// DownSuffix is the extension appended to every "down" migration file.
+const DownSuffix = ".down.sql"
+
+// timestampLayout is the layout used to stamp new migration file names so
+// they sort chronologically alongside every other migration on disk.
+const timestampLayout = "20060102150405"
+
+// Placeholder returns the bind-variable placeholder for the n-th (1-based)
+// argument in a parameterized query, e.g. "?" for MySQL/SQLite or "$1" for
+// PostgreSQL.
+type Placeholder func(n int) string
+
+// Question is the Placeholder used by drivers that bind with "?" (MySQL,
+// SQLite).
+func Question(n int) string {
+	return "?"
+}
+
+// Dollar is the Placeholder used by drivers that bind with "$1", "$2", ...
+// (PostgreSQL).
+func Dollar(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
+
+// Engine applies and rolls back "up"/"down" migration files in folder
+// against the tracking table named table, recording the outcome of the
+// last operation performed as a stable Event keyword plus the Output file
+// it acted on.
+type Engine struct {
+	db          *sql.DB
+	folder      string
+	table       string
+	placeholder Placeholder
+
+	output string
+	event  string
+}
+
+// New returns an Engine backed by db, managing migration files in folder
+// and tracking applied state in table. placeholder supplies the
+// bind-variable syntax the underlying driver expects.
+func New(db *sql.DB, folder, table string, placeholder Placeholder) *Engine {
+	return &Engine{db: db, folder: folder, table: table, placeholder: placeholder}
+}
+
+// EnsureTable creates the migration tracking table if it does not already
+// exist.
+func (e *Engine) EnsureTable() error {
+	_, err := e.db.Exec(fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %v (migration VARCHAR(255) NOT NULL PRIMARY KEY, applied_at VARCHAR(32) NOT NULL)",
+		e.table))
+	return err
+}
+
+// Create adds a new timestamped migration file pair to the migration
+// folder.
+func (e *Engine) Create(description string) error {
+	name := fmt.Sprintf("%v_%v", time.Now().Format(timestampLayout), sanitize(description))
+
+	upPath := filepath.Join(e.folder, name+UpSuffix)
+	downPath := filepath.Join(e.folder, name+DownSuffix)
+
+	if err := os.WriteFile(upPath, []byte("-- "+description+"\n"), 0644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(downPath, []byte("-- "+description+"\n"), 0644); err != nil {
+		return err
+	}
+
+	e.event = "created"
+	e.output = filepath.Base(upPath)
+	return nil
+}
+
+// sanitize converts a migration description into the lowercase,
+// underscore-separated form used in file names.
+func sanitize(description string) string {
+	return strings.ToLower(strings.ReplaceAll(description, " ", "_"))
+}
+
+// UpAll runs every "up" file that has not been applied yet.
+func (e *Engine) UpAll() error {
+	names, err := e.MigrationNames()
+	if err != nil {
+		return err
+	}
+
+	return e.UpSteps(len(names))
+}
+
+// DownAll runs every "down" file to return the database to empty.
+func (e *Engine) DownAll() error {
+	applied, err := e.AppliedMigrations()
+	if err != nil {
+		return err
+	}
+
+	return e.DownSteps(len(applied))
+}
+
+// UpSteps applies n more "up" files, advancing the database n iterations
+// past its current position.
+func (e *Engine) UpSteps(n int) error {
+	for i := 0; i < n; i++ {
+		if err := e.UpOne(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DownSteps applies n "down" files, rolling the database back n
+// iterations from its current position.
+func (e *Engine) DownSteps(n int) error {
+	for i := 0; i < n; i++ {
+		if err := e.DownOne(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// To rolls the database forward or backward, using UpSteps or DownSteps,
+// until migrationID is the current head. Direction is computed from the
+// current Position.
+func (e *Engine) To(migrationID string) error {
+	names, err := e.MigrationNames()
+	if err != nil {
+		return err
+	}
+
+	target, err := resolveTarget(names, migrationID)
+	if err != nil {
+		return err
+	}
+
+	steps, up, ok := planTo(e.Position(), target)
+	if !ok {
+		e.event, e.output = "", ""
+		return nil
+	}
+
+	if up {
+		return e.UpSteps(steps)
+	}
+	return e.DownSteps(steps)
+}
+
+// resolveTarget returns the 1-based position of migrationID within names,
+// a slice of migration names sorted oldest to newest, or an error if
+// migrationID isn't found.
+func resolveTarget(names []string, migrationID string) (int, error) {
+	for i, name := range names {
+		if name == migrationID {
+			return i + 1, nil
+		}
+	}
+
+	return 0, fmt.Errorf("migration not found: %v", migrationID)
+}
+
+// planTo returns the number of steps and the direction (up when true, down
+// when false) needed to move from current to target. ok is false when
+// current already equals target and nothing needs to run.
+func planTo(current, target int) (steps int, up bool, ok bool) {
+	switch {
+	case target > current:
+		return target - current, true, true
+	case target < current:
+		return current - target, false, true
+	default:
+		return 0, false, false
+	}
+}
+
+// UpOne runs only the next "up" file that has not been applied yet.
+func (e *Engine) UpOne() error {
+	names, err := e.MigrationNames()
+	if err != nil {
+		return err
+	}
+
+	applied, err := e.AppliedMigrations()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if _, ok := applied[name]; ok {
+			continue
+		}
+
+		if err := e.runFile(name, UpSuffix); err != nil {
+			return err
+		}
+
+		query := fmt.Sprintf("INSERT INTO %v (migration, applied_at) VALUES (%v, %v)",
+			e.table, e.placeholder(1), e.placeholder(2))
+		if _, err := e.db.Exec(query, name, time.Now().Format(time.RFC3339)); err != nil {
+			return err
+		}
+
+		e.event = "applied"
+		e.output = name + UpSuffix
+		return nil
+	}
+
+	e.event, e.output = "", ""
+	return nil
+}
+
+// DownOne runs only the current "down" file, the most recently applied
+// migration.
+func (e *Engine) DownOne() error {
+	applied, err := e.AppliedMigrations()
+	if err != nil {
+		return err
+	}
+
+	name := latestApplied(applied)
+	if len(name) == 0 {
+		e.event, e.output = "", ""
+		return nil
+	}
+
+	if err := e.runFile(name, DownSuffix); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %v WHERE migration = %v", e.table, e.placeholder(1))
+	if _, err := e.db.Exec(query, name); err != nil {
+		return err
+	}
+
+	e.event = "rolled_back"
+	e.output = name + DownSuffix
+	return nil
+}
+
+// runFile reads the migration file for name with the given suffix and
+// executes it against the database.
+func (e *Engine) runFile(name, suffix string) error {
+	path := filepath.Join(e.folder, name+suffix)
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.db.Exec(string(contents))
+	return err
+}
+
+// latestApplied returns the name of the most recently applied migration,
+// or "" when applied is empty. Migration names sort chronologically
+// because they are stamped with timestampLayout, so the greatest name is
+// also the most recent.
+func latestApplied(applied map[string]string) string {
+	var latest string
+	for name := range applied {
+		if name > latest {
+			latest = name
+		}
+	}
+	return latest
+}
+
+// AppliedMigrations returns every migration name recorded in the tracking
+// table along with the timestamp it was applied.
+func (e *Engine) AppliedMigrations() (map[string]string, error) {
+	applied := map[string]string{}
+
+	rows, err := e.db.Query("SELECT migration, applied_at FROM " + e.table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, appliedAt string
+		if err := rows.Scan(&name, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[name] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
+
+// MigrationNames returns the name of every "up" migration file in the
+// migration folder, sorted oldest to newest.
+func (e *Engine) MigrationNames() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(e.folder, "*"+UpSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(files))
+	for i, f := range files {
+		names[i] = strings.TrimSuffix(filepath.Base(f), UpSuffix)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Position returns how many migrations are currently applied.
+func (e *Engine) Position() int {
+	applied, err := e.AppliedMigrations()
+	if err != nil {
+		return 0
+	}
+	return len(applied)
+}
+
+// Status returns the name of the last migration applied.
+func (e *Engine) Status() string {
+	applied, err := e.AppliedMigrations()
+	if err != nil {
+		return ""
+	}
+	return latestApplied(applied)
+}
+
+// Output returns the file the last operation performed acted on, e.g.
+// "20240101120000_add_users.up.sql", or "" when the last operation had
+// nothing to do.
+func (e *Engine) Output() string {
+	return e.output
+}
+
+// Event returns the stable keyword describing the last operation
+// performed: "created", "applied", "rolled_back", or "" when there was
+// nothing to do.
+func (e *Engine) Event() string {
+	return e.event
+}
@@ -0,0 +1,311 @@
+package engine
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeDriver is a minimal in-memory database/sql driver, built only from
+// the standard library, that tracks rows inserted into and deleted from
+// the migration tracking table. It lets UpOne/DownOne/AppliedMigrations be
+// exercised without a real MySQL/PostgreSQL/SQLite server, standing in for
+// whichever of the three this Engine would normally be wired to.
+type fakeDriver struct {
+	mu      sync.Mutex
+	applied map[string]string
+}
+
+var fakeSeq int
+var fakeSeqMu sync.Mutex
+
+// newFakeDB registers a fresh fakeDriver under a unique name and opens it,
+// so each test gets its own isolated tracking table.
+func newFakeDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	fakeSeqMu.Lock()
+	fakeSeq++
+	name := fmt.Sprintf("engine-fake-%d", fakeSeq)
+	fakeSeqMu.Unlock()
+
+	sql.Register(name, &fakeDriver{applied: map[string]string{}})
+
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatalf("sql.Open() = %v", err)
+	}
+	return db
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{d: d}, nil
+}
+
+type fakeConn struct {
+	d *fakeDriver
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{c: c, query: query}, nil
+}
+func (c *fakeConn) Close() error { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("fakeDriver: transactions not supported")
+}
+
+type fakeStmt struct {
+	c     *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	q := strings.TrimSpace(s.query)
+	d := s.c.d
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(q, "CREATE TABLE"):
+		// Tracking table always "exists" in the fake.
+	case strings.HasPrefix(q, "INSERT INTO"):
+		d.applied[fmt.Sprintf("%v", args[0])] = fmt.Sprintf("%v", args[1])
+	case strings.HasPrefix(q, "DELETE FROM"):
+		delete(d.applied, fmt.Sprintf("%v", args[0]))
+	default:
+		// A migration file's own SQL body: the fake has no schema to
+		// apply it to, so just accept it.
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	d := s.c.d
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	rows := &fakeRows{}
+	for migration, appliedAt := range d.applied {
+		rows.data = append(rows.data, [2]string{migration, appliedAt})
+	}
+	return rows, nil
+}
+
+type fakeRows struct {
+	data [][2]string
+	i    int
+}
+
+func (r *fakeRows) Columns() []string { return []string{"migration", "applied_at"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.i >= len(r.data) {
+		return io.EOF
+	}
+	dest[0] = r.data[r.i][0]
+	dest[1] = r.data[r.i][1]
+	r.i++
+	return nil
+}
+
+// writeMigration creates a name.up.sql/name.down.sql pair in folder with
+// harmless bodies.
+func writeMigration(t *testing.T, folder, name string) {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(folder, name+UpSuffix), []byte("-- up\n"), 0644); err != nil {
+		t.Fatalf("os.WriteFile(up) = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(folder, name+DownSuffix), []byte("-- down\n"), 0644); err != nil {
+		t.Fatalf("os.WriteFile(down) = %v", err)
+	}
+}
+
+func TestEngineUpOneDownOne(t *testing.T) {
+	folder := t.TempDir()
+	writeMigration(t, folder, "1_a")
+	writeMigration(t, folder, "2_b")
+
+	e := New(newFakeDB(t), folder, "migrations", Question)
+	if err := e.EnsureTable(); err != nil {
+		t.Fatalf("EnsureTable() = %v", err)
+	}
+
+	if err := e.UpOne(); err != nil {
+		t.Fatalf("UpOne() = %v", err)
+	}
+	if e.Event() != "applied" || e.Output() != "1_a"+UpSuffix {
+		t.Errorf("after first UpOne(): Event()=%q Output()=%q, want applied/%q", e.Event(), e.Output(), "1_a"+UpSuffix)
+	}
+	if got := e.Position(); got != 1 {
+		t.Errorf("Position() = %v, want 1", got)
+	}
+
+	if err := e.UpOne(); err != nil {
+		t.Fatalf("UpOne() = %v", err)
+	}
+	if e.Output() != "2_b"+UpSuffix {
+		t.Errorf("after second UpOne(): Output()=%q, want %q", e.Output(), "2_b"+UpSuffix)
+	}
+
+	if err := e.UpOne(); err != nil {
+		t.Fatalf("UpOne() = %v", err)
+	}
+	if e.Event() != "" || e.Output() != "" {
+		t.Errorf("UpOne() with nothing pending: Event()=%q Output()=%q, want empty", e.Event(), e.Output())
+	}
+
+	if err := e.DownOne(); err != nil {
+		t.Fatalf("DownOne() = %v", err)
+	}
+	if e.Event() != "rolled_back" || e.Output() != "2_b"+DownSuffix {
+		t.Errorf("after DownOne(): Event()=%q Output()=%q, want rolled_back/%q", e.Event(), e.Output(), "2_b"+DownSuffix)
+	}
+	if got := e.Position(); got != 1 {
+		t.Errorf("Position() after rollback = %v, want 1", got)
+	}
+	if got := e.Status(); got != "1_a" {
+		t.Errorf("Status() = %q, want %q", got, "1_a")
+	}
+}
+
+func TestEngineUpAllDownAll(t *testing.T) {
+	folder := t.TempDir()
+	writeMigration(t, folder, "1_a")
+	writeMigration(t, folder, "2_b")
+	writeMigration(t, folder, "3_c")
+
+	e := New(newFakeDB(t), folder, "migrations", Dollar)
+	if err := e.EnsureTable(); err != nil {
+		t.Fatalf("EnsureTable() = %v", err)
+	}
+
+	if err := e.UpAll(); err != nil {
+		t.Fatalf("UpAll() = %v", err)
+	}
+	if got := e.Position(); got != 3 {
+		t.Errorf("Position() after UpAll() = %v, want 3", got)
+	}
+
+	if err := e.DownAll(); err != nil {
+		t.Fatalf("DownAll() = %v", err)
+	}
+	if got := e.Position(); got != 0 {
+		t.Errorf("Position() after DownAll() = %v, want 0", got)
+	}
+}
+
+func TestEngineStepsNoop(t *testing.T) {
+	e := New(nil, "", "", Question)
+
+	if err := e.UpSteps(0); err != nil {
+		t.Errorf("UpSteps(0) = %v, want nil", err)
+	}
+	if err := e.DownSteps(0); err != nil {
+		t.Errorf("DownSteps(0) = %v, want nil", err)
+	}
+	if e.Event() != "" || e.Output() != "" {
+		t.Errorf("zero-step no-op touched state: Event()=%q Output()=%q", e.Event(), e.Output())
+	}
+}
+
+func TestResolveTarget(t *testing.T) {
+	names := []string{"1_a", "2_b", "3_c"}
+
+	cases := []struct {
+		name        string
+		migrationID string
+		want        int
+		wantErr     bool
+	}{
+		{name: "first", migrationID: "1_a", want: 1},
+		{name: "last", migrationID: "3_c", want: 3},
+		{name: "not found", migrationID: "9_missing", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := resolveTarget(names, c.migrationID)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("resolveTarget(%q) = %v, want error", c.migrationID, got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("resolveTarget(%q) returned unexpected error: %v", c.migrationID, err)
+			}
+			if got != c.want {
+				t.Errorf("resolveTarget(%q) = %v, want %v", c.migrationID, got, c.want)
+			}
+		})
+	}
+}
+
+func TestPlanTo(t *testing.T) {
+	cases := []struct {
+		name           string
+		current        int
+		target         int
+		wantSteps      int
+		wantUp, wantOk bool
+	}{
+		{name: "forward", current: 1, target: 3, wantSteps: 2, wantUp: true, wantOk: true},
+		{name: "backward", current: 3, target: 1, wantSteps: 2, wantUp: false, wantOk: true},
+		{name: "already there", current: 2, target: 2, wantSteps: 0, wantUp: false, wantOk: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			steps, up, ok := planTo(c.current, c.target)
+			if steps != c.wantSteps || up != c.wantUp || ok != c.wantOk {
+				t.Errorf("planTo(%v, %v) = (%v, %v, %v), want (%v, %v, %v)",
+					c.current, c.target, steps, up, ok, c.wantSteps, c.wantUp, c.wantOk)
+			}
+		})
+	}
+}
+
+func TestEngineTo(t *testing.T) {
+	folder := t.TempDir()
+	writeMigration(t, folder, "1_a")
+	writeMigration(t, folder, "2_b")
+	writeMigration(t, folder, "3_c")
+
+	e := New(newFakeDB(t), folder, "migrations", Question)
+	if err := e.EnsureTable(); err != nil {
+		t.Fatalf("EnsureTable() = %v", err)
+	}
+
+	if err := e.To("2_b"); err != nil {
+		t.Fatalf("To(2_b) = %v", err)
+	}
+	if got := e.Position(); got != 2 {
+		t.Errorf("Position() after To(2_b) = %v, want 2", got)
+	}
+
+	if err := e.To("1_a"); err != nil {
+		t.Fatalf("To(1_a) = %v", err)
+	}
+	if got := e.Position(); got != 1 {
+		t.Errorf("Position() after To(1_a) = %v, want 1", got)
+	}
+
+	if err := e.To("missing"); err == nil {
+		t.Error("To(missing) = nil, want error")
+	}
+}
@@ -0,0 +1,46 @@
+// Package mysql applies and rolls back "up" and "down" SQL migration files
+// against a MySQL database, using the shared migration engine for
+// everything but the DSN and bind-variable placeholder syntax.
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/go-sql-driver/mysql"
+
+	"github.com/blue-jay-fork/core/storage"
+	"github.com/blue-jay-fork/core/storage/migration/internal/engine"
+)
+
+// Configuration holds the MySQL connection and migration settings needed to
+// create a new Migration.
+type Configuration struct {
+	storage.MySQL
+}
+
+// Migration manages the migration files and tracking table for a MySQL
+// database. It implements storage.MigrationDriver.
+type Migration struct {
+	*engine.Engine
+}
+
+// New connects to the database described by the configuration, ensures the
+// migration tracking table exists, and returns a Migration ready to create
+// and apply migration files.
+func (c *Configuration) New() (*Migration, error) {
+	dsn := fmt.Sprintf("%v:%v@tcp(%v)/%v%v", c.Username, c.Password, c.Hostname,
+		c.Database, c.Parameter)
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	e := engine.New(db, c.Migration.Folder, c.Migration.Table, engine.Question)
+	if err := e.EnsureTable(); err != nil {
+		return nil, err
+	}
+
+	return &Migration{Engine: e}, nil
+}
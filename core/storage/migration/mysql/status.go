@@ -0,0 +1,69 @@
+package mysql
+
+import "sort"
+
+// migrationMissing marks a migration that is recorded as applied in the
+// tracking table but whose file no longer exists on disk.
+const migrationMissing = "MISSING"
+
+// State describes a single migration's applied state for the status
+// listing. Applied is either the timestamp the migration ran, "no", or
+// "MISSING" when the migration is recorded in the database but its file is
+// gone from disk.
+type State struct {
+	Migration string `json:"migration"`
+	Applied   string `json:"applied"`
+}
+
+// StatusDetail enumerates every "up" migration file in the migration
+// folder, cross-references it against the migration tracking table, and
+// returns one State per migration found on disk or in the table. This is
+// MySQL-specific: migrate:postgres and migrate:sqlite only expose the
+// single-line Status() every storage.MigrationDriver provides.
+func (m *Migration) StatusDetail() ([]State, error) {
+	applied, err := m.AppliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := m.MigrationNames()
+	if err != nil {
+		return nil, err
+	}
+
+	return buildStates(names, applied), nil
+}
+
+// buildStates merges the migration names found on disk with the applied
+// map from the tracking table into one State per migration, sorted by
+// name, flagging anything recorded as applied whose file is missing from
+// disk as MISSING.
+func buildStates(onDisk []string, applied map[string]string) []State {
+	seen := map[string]bool{}
+	var states []State
+
+	for _, name := range onDisk {
+		seen[name] = true
+
+		appliedAt, ok := applied[name]
+		if !ok {
+			appliedAt = "no"
+		}
+
+		states = append(states, State{Migration: name, Applied: appliedAt})
+	}
+
+	for name := range applied {
+		if seen[name] {
+			continue
+		}
+
+		states = append(states, State{Migration: name, Applied: migrationMissing})
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Migration < states[j].Migration
+	})
+
+	return states
+}
@@ -0,0 +1,53 @@
+package mysql
+
+import "testing"
+
+func TestBuildStates(t *testing.T) {
+	cases := []struct {
+		name    string
+		onDisk  []string
+		applied map[string]string
+		want    []State
+	}{
+		{
+			name:    "applied and pending",
+			onDisk:  []string{"1_a", "2_b"},
+			applied: map[string]string{"1_a": "2024-01-01T00:00:00Z"},
+			want: []State{
+				{Migration: "1_a", Applied: "2024-01-01T00:00:00Z"},
+				{Migration: "2_b", Applied: "no"},
+			},
+		},
+		{
+			name:    "missing from disk",
+			onDisk:  []string{"1_a"},
+			applied: map[string]string{"1_a": "2024-01-01T00:00:00Z", "0_gone": "2023-01-01T00:00:00Z"},
+			want: []State{
+				{Migration: "0_gone", Applied: migrationMissing},
+				{Migration: "1_a", Applied: "2024-01-01T00:00:00Z"},
+			},
+		},
+		{
+			name:    "nothing on disk or applied",
+			onDisk:  nil,
+			applied: map[string]string{},
+			want:    nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := buildStates(c.onDisk, c.applied)
+
+			if len(got) != len(c.want) {
+				t.Fatalf("buildStates() = %v, want %v", got, c.want)
+			}
+
+			for i := range got {
+				if got[i] != c.want[i] {
+					t.Errorf("state %v = %v, want %v", i, got[i], c.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Package postgres applies and rolls back "up" and "down" SQL migration
+// files against a PostgreSQL database, using the shared migration engine
+// for everything but the DSN and bind-variable placeholder syntax.
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/lib/pq"
+
+	"github.com/blue-jay-fork/core/storage"
+	"github.com/blue-jay-fork/core/storage/migration/internal/engine"
+)
+
+// Configuration holds the PostgreSQL connection and migration settings
+// needed to create a new Migration.
+type Configuration struct {
+	storage.Postgres
+}
+
+// Migration manages the migration files and tracking table for a
+// PostgreSQL database. It implements storage.MigrationDriver.
+type Migration struct {
+	*engine.Engine
+}
+
+// New connects to the database described by the configuration, ensures the
+// migration tracking table exists, and returns a Migration ready to create
+// and apply migration files.
+func (c *Configuration) New() (*Migration, error) {
+	dsn := fmt.Sprintf("host=%v user=%v password=%v dbname=%v %v", c.Hostname,
+		c.Username, c.Password, c.Database, c.Parameter)
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	e := engine.New(db, c.Migration.Folder, c.Migration.Table, engine.Dollar)
+	if err := e.EnsureTable(); err != nil {
+		return nil, err
+	}
+
+	return &Migration{Engine: e}, nil
+}
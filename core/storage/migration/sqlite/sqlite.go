@@ -0,0 +1,43 @@
+// Package sqlite applies and rolls back "up" and "down" SQL migration files
+// against a SQLite database, using the shared migration engine for
+// everything but opening the file and the bind-variable placeholder
+// syntax.
+package sqlite
+
+import (
+	"database/sql"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/blue-jay-fork/core/storage"
+	"github.com/blue-jay-fork/core/storage/migration/internal/engine"
+)
+
+// Configuration holds the SQLite connection and migration settings needed
+// to create a new Migration.
+type Configuration struct {
+	storage.SQLite
+}
+
+// Migration manages the migration files and tracking table for a SQLite
+// database. It implements storage.MigrationDriver.
+type Migration struct {
+	*engine.Engine
+}
+
+// New opens the database file described by the configuration, ensures the
+// migration tracking table exists, and returns a Migration ready to create
+// and apply migration files.
+func (c *Configuration) New() (*Migration, error) {
+	db, err := sql.Open("sqlite3", c.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	e := engine.New(db, c.Migration.Folder, c.Migration.Table, engine.Question)
+	if err := e.EnsureTable(); err != nil {
+		return nil, err
+	}
+
+	return &Migration{Engine: e}, nil
+}
@@ -0,0 +1,76 @@
+// Package storage holds the connection settings for every database Jay
+// knows how to migrate and generate code against.
+package storage
+
+// Info holds the connection settings for every supported database as read
+// from the env.json (or env.yaml) configuration file.
+//
+// The yaml tags here are not optional decoration: yaml.v2 lowercases
+// untagged field names by default, so without them every PascalCase key
+// env.json (and the env.yaml config.ConvertToYAML derives from it) uses
+// would fail to match and silently decode as zero values.
+type Info struct {
+	MySQL    MySQL    `yaml:"MySQL"`
+	Postgres Postgres `yaml:"Postgres"`
+	SQLite   SQLite   `yaml:"SQLite"`
+}
+
+// Migration holds the folder and table settings shared by every migration
+// driver.
+type Migration struct {
+	Folder string `yaml:"Folder"`
+	Table  string `yaml:"Table"`
+}
+
+// MySQL holds the connection settings for a MySQL database.
+type MySQL struct {
+	Hostname  string    `yaml:"Hostname"`
+	Username  string    `yaml:"Username"`
+	Password  string    `yaml:"Password"`
+	Database  string    `yaml:"Database"`
+	Parameter string    `yaml:"Parameter"`
+	Migration Migration `yaml:"Migration"`
+}
+
+// Postgres holds the connection settings for a PostgreSQL database.
+type Postgres struct {
+	Hostname  string    `yaml:"Hostname"`
+	Username  string    `yaml:"Username"`
+	Password  string    `yaml:"Password"`
+	Database  string    `yaml:"Database"`
+	Parameter string    `yaml:"Parameter"`
+	Migration Migration `yaml:"Migration"`
+}
+
+// SQLite holds the connection settings for a SQLite database.
+type SQLite struct {
+	Path      string    `yaml:"Path"`
+	Migration Migration `yaml:"Migration"`
+}
+
+// MigrationDriver is implemented by every database-specific migration
+// package (mysql, postgres, sqlite) so main.go can dispatch migrate:*
+// commands generically instead of duplicating the switch per driver.
+type MigrationDriver interface {
+	// Create adds a new migration file pair to the migration folder.
+	Create(description string) error
+	// UpAll runs every "up" file that has not been applied yet.
+	UpAll() error
+	// DownAll runs every "down" file to return the database to empty.
+	DownAll() error
+	// UpOne runs only the next "up" file.
+	UpOne() error
+	// DownOne runs only the current "down" file.
+	DownOne() error
+	// Position returns how many migrations are currently applied.
+	Position() int
+	// Status returns the name of the last migration applied.
+	Status() string
+	// Output returns the file the last operation performed acted on, or
+	// "" when it had nothing to do.
+	Output() string
+	// Event returns the stable keyword describing the last operation
+	// performed ("created", "applied", "rolled_back", or "" for no-op),
+	// suitable for structured logging.
+	Event() string
+}
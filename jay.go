@@ -3,21 +3,26 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"text/tabwriter"
 
+	"github.com/blue-jay-fork/core/config"
 	"github.com/blue-jay-fork/core/env"
 	"github.com/blue-jay-fork/core/file"
 	"github.com/blue-jay-fork/core/find"
 	"github.com/blue-jay-fork/core/generate"
-	"github.com/blue-jay-fork/core/jsonconfig"
+	jaylog "github.com/blue-jay-fork/core/log"
 	"github.com/blue-jay-fork/core/replace"
 	"github.com/blue-jay-fork/core/storage"
 	mysqlMigration "github.com/blue-jay-fork/core/storage/migration/mysql"
+	postgresMigration "github.com/blue-jay-fork/core/storage/migration/postgres"
+	sqliteMigration "github.com/blue-jay-fork/core/storage/migration/sqlite"
 
 	"gopkg.in/alecthomas/kingpin.v2"
 )
@@ -26,6 +31,8 @@ var (
 	app = kingpin.New("jay", "A command-line application to build faster with Blue Jay.")
 
 	flagConfigFile = app.Flag("config", "Path to the env.json file.").Short('c').String()
+	flagLogLevel   = app.Flag("log-level", "Minimum severity to log: debug, info, warn, error, or fatal.").Default("info").String()
+	flagLogFormat  = app.Flag("log-format", "Output format for log events: text or json.").Default("text").String()
 
 	cFind          = app.Command("find", "Search for files containing matching text.")
 	cFindFolder    = cFind.Arg("folder", "Folder to search").Required().String()
@@ -47,22 +54,77 @@ var (
 	cEnvMake      = cEnv.Command("make", "Create a new env.json file.")
 	cEnvKeyshow   = cEnv.Command("keyshow", "Show a new set of session keys.")
 	cEnvKeyUpdate = cEnv.Command("keyupdate", "Update env.json with a new set of session keys.")
-
-	cMigrateMySQL         = app.Command("migrate:mysql", "Migrate MySQL to different states using 'up' and 'down' files.")
-	cMigrateMySQLMake     = cMigrateMySQL.Command("make", "Create a migration file.")
-	cMigrateMySQLMakeDesc = cMigrateMySQLMake.Arg("description", "Description for the migration file. Spaces will be converted to underscores and all characters will be make lowercase.").Required().String()
-	cMigrateMySQLAll      = cMigrateMySQL.Command("all", "Run all 'up' files to advance the database to the latest.")
-	cMigrateMySQLReset    = cMigrateMySQL.Command("reset", "Run all 'down' files to rollback the database to empty.")
-	cMigrateMySQLRefresh  = cMigrateMySQL.Command("refresh", "Run all 'down' files and then 'up' files so the database is fresh and updated.")
-	cMigrateMySQLStatus   = cMigrateMySQL.Command("status", "View the last 'up' file performed on the database.")
-	cMigrateMySQLUp       = cMigrateMySQL.Command("up", "Apply only the next 'up' file to the database to advance the database one iteration.")
-	cMigrateMySQLDown     = cMigrateMySQL.Command("down", "Apply only the current 'down' file to the database to rollback the database one iteration.")
+	cEnvConvert   = cEnv.Command("convert", "Convert env.json to env.yaml.")
+	cEnvConvertTo = cEnvConvert.Flag("to", "Target format to convert to.").Default("yaml").String()
+
+	cMigrateMySQL             = app.Command("migrate:mysql", "Migrate MySQL to different states using 'up' and 'down' files.")
+	cMigrateMySQLMake         = cMigrateMySQL.Command("make", "Create a migration file.")
+	cMigrateMySQLMakeDesc     = cMigrateMySQLMake.Arg("description", "Description for the migration file. Spaces will be converted to underscores and all characters will be make lowercase.").Required().String()
+	cMigrateMySQLAll          = cMigrateMySQL.Command("all", "Run all 'up' files to advance the database to the latest.")
+	cMigrateMySQLReset        = cMigrateMySQL.Command("reset", "Run all 'down' files to rollback the database to empty.")
+	cMigrateMySQLRefresh      = cMigrateMySQL.Command("refresh", "Run all 'down' files and then 'up' files so the database is fresh and updated.")
+	cMigrateMySQLRefreshSteps = cMigrateMySQLRefresh.Flag("steps", "Number of migrations to roll back and reapply instead of the full history.").Default("0").Int()
+	cMigrateMySQLStatus       = cMigrateMySQL.Command("status", "View every migration and whether it has been applied.")
+	cMigrateMySQLStatusFormat = cMigrateMySQLStatus.Flag("format", "Output format: table or json.").Default("table").String()
+	cMigrateMySQLUp           = cMigrateMySQL.Command("up", "Apply the next 'up' file(s) to the database to advance the database.")
+	cMigrateMySQLUpSteps      = cMigrateMySQLUp.Flag("steps", "Number of 'up' files to apply.").Default("1").Int()
+	cMigrateMySQLDown         = cMigrateMySQL.Command("down", "Apply the current 'down' file(s) to the database to rollback the database.")
+	cMigrateMySQLDownSteps    = cMigrateMySQLDown.Flag("steps", "Number of 'down' files to apply.").Default("1").Int()
+	cMigrateMySQLDownTo       = cMigrateMySQLDown.Flag("to", "Roll back or forward until this migration is the current head. Overrides --steps.").String()
+
+	cMigratePostgres         = app.Command("migrate:postgres", "Migrate PostgreSQL to different states using 'up' and 'down' files.")
+	cMigratePostgresMake     = cMigratePostgres.Command("make", "Create a migration file.")
+	cMigratePostgresMakeDesc = cMigratePostgresMake.Arg("description", "Description for the migration file. Spaces will be converted to underscores and all characters will be make lowercase.").Required().String()
+	cMigratePostgresAll      = cMigratePostgres.Command("all", "Run all 'up' files to advance the database to the latest.")
+	cMigratePostgresReset    = cMigratePostgres.Command("reset", "Run all 'down' files to rollback the database to empty.")
+	cMigratePostgresRefresh  = cMigratePostgres.Command("refresh", "Run all 'down' files and then 'up' files so the database is fresh and updated.")
+	cMigratePostgresStatus   = cMigratePostgres.Command("status", "View the last 'up' file performed on the database.")
+	cMigratePostgresUp       = cMigratePostgres.Command("up", "Apply only the next 'up' file to the database to advance the database one iteration.")
+	cMigratePostgresDown     = cMigratePostgres.Command("down", "Apply only the current 'down' file to the database to rollback the database one iteration.")
+
+	cMigrateSQLite         = app.Command("migrate:sqlite", "Migrate SQLite to different states using 'up' and 'down' files.")
+	cMigrateSQLiteMake     = cMigrateSQLite.Command("make", "Create a migration file.")
+	cMigrateSQLiteMakeDesc = cMigrateSQLiteMake.Arg("description", "Description for the migration file. Spaces will be converted to underscores and all characters will be make lowercase.").Required().String()
+	cMigrateSQLiteAll      = cMigrateSQLite.Command("all", "Run all 'up' files to advance the database to the latest.")
+	cMigrateSQLiteReset    = cMigrateSQLite.Command("reset", "Run all 'down' files to rollback the database to empty.")
+	cMigrateSQLiteRefresh  = cMigrateSQLite.Command("refresh", "Run all 'down' files and then 'up' files so the database is fresh and updated.")
+	cMigrateSQLiteStatus   = cMigrateSQLite.Command("status", "View the last 'up' file performed on the database.")
+	cMigrateSQLiteUp       = cMigrateSQLite.Command("up", "Apply only the next 'up' file to the database to advance the database one iteration.")
+	cMigrateSQLiteDown     = cMigrateSQLite.Command("down", "Apply only the current 'down' file to the database to rollback the database one iteration.")
 
 	cGenerate     = app.Command("generate", "Generate files from template pairs.")
 	cGenerateTmpl = cGenerate.Arg("folder/template", "Template pair name. Don't include an extension.").Required().String()
 	cGenerateVars = stringList(cGenerate.Arg("key:value", "Key and value required for the template pair."))
+
+	cGenerateAPI       = app.Command("api", "Generate models, controllers, and routes from an existing database, or from a bundled example when no connection is given.")
+	cGenerateAPIDriver = cGenerateAPI.Flag("driver", "Database driver to introspect: mysql, postgres, or sqlite3.").Default("mysql").String()
+	cGenerateAPIConn   = cGenerateAPI.Flag("conn", "Database connection string to introspect. Leave empty to generate an example CRUD resource instead.").String()
+	cGenerateAPITables = cGenerateAPI.Flag("tables", "Comma-separated list of tables to generate. Empty generates every table.").Default("").String()
 )
 
+// logger is the leveled logger every command routes its output through. It
+// is created in main once the command line has been parsed.
+var logger *jaylog.Logger
+
+// fatalErr logs err as a stable "error" event with the message carried in
+// Fields, rather than folding it into the event string, so JSON-format
+// output stays parseable, then exits the process.
+func fatalErr(err error) {
+	logger.Fatal("error", jaylog.Fields{"error": err.Error()})
+}
+
+// logMigrationResult logs the outcome of the last operation performed
+// against mig using its stable Event keyword ("created", "applied",
+// "rolled_back") plus the file it acted on, falling back to a "noop"
+// event when mig had nothing to do.
+func logMigrationResult(mig storage.MigrationDriver) {
+	event := mig.Event()
+	if len(event) == 0 {
+		event = "noop"
+	}
+	logger.Info(event, jaylog.Fields{"file": mig.Output()})
+}
+
 // init sets runtime settings.
 func init() {
 	// Verbose logging with file name and line number
@@ -80,11 +142,20 @@ func main() {
 	argList := os.Args[1:]
 	arg := kingpin.MustParse(app.Parse(argList))
 
+	cmd := ""
+	if len(argList) > 0 {
+		cmd = argList[0]
+	}
+	logger = jaylog.New(cmd, jaylog.ParseLevel(*flagLogLevel), jaylog.ParseFormat(*flagLogFormat))
+
 	commandFind(arg)
 	commandReplace(arg)
 	commandEnv(arg)
 	commandMigrateMySQL(arg, argList)
+	commandMigratePostgres(arg, argList)
+	commandMigrateSQLite(arg, argList)
 	commandGenerate(arg, argList)
+	commandGenerateAPI(arg, argList)
 }
 
 func commandFind(arg string) {
@@ -96,11 +167,11 @@ func commandFind(arg string) {
 			cFindRecursive,
 			cFindFilename)
 		if err != nil {
-			app.Fatalf("%v", err)
+			fatalErr(err)
 		}
 
 		for _, line := range contents {
-			fmt.Println(line)
+			logger.Info("match", jaylog.Fields{"line": line})
 		}
 	}
 }
@@ -116,11 +187,11 @@ func commandReplace(arg string) {
 			cReplaceFilename,
 			cReplaceCommit)
 		if err != nil {
-			app.Fatalf("%v", err)
+			fatalErr(err)
 		}
 
 		for _, line := range contents {
-			fmt.Println(line)
+			logger.Info("replaced", jaylog.Fields{"line": line})
 		}
 	}
 }
@@ -130,36 +201,49 @@ func commandEnv(arg string) {
 	case cEnvMake.FullCommand():
 		err := file.Copy("env.json.example", "env.json")
 		if err != nil {
-			app.Fatalf("%v", err)
+			fatalErr(err)
 		}
 		err = env.UpdateFileKeys("env.json")
 		if err != nil {
-			app.Fatalf("%v", err)
+			fatalErr(err)
 		}
 
 		p, err := filepath.Abs(".")
 		if err != nil {
-			app.Fatalf("%v", err)
+			fatalErr(err)
 		}
 		config := filepath.Join(p, "env.json")
 		if !file.Exists(config) {
-			app.Fatalf("%v", err)
+			logger.Fatal("not_created", jaylog.Fields{"file": "env.json"})
 		}
 
-		fmt.Println("File, env.json, created successfully with new session keys.")
-		fmt.Println("Set your environment variable, JAYCONFIG, to:")
-		fmt.Println(config)
+		logger.Info("created", jaylog.Fields{"file": config})
+		logger.Info("hint", jaylog.Fields{"env_var": "JAYCONFIG", "file": config,
+			"yaml": "JAYCONFIG may also point to an env.yaml file; run `jay env convert --to yaml` to create one."})
 	case cEnvKeyshow.FullCommand():
-		fmt.Println("Paste these into your env.json file:")
-		fmt.Printf(`    "AuthKey":"%v",`+"\n", env.EncodedKey(64))
-		fmt.Printf(`    "EncryptKey":"%v",`+"\n", env.EncodedKey(32))
-		fmt.Printf(`    "CSRFKey":"%v",`+"\n", env.EncodedKey(32))
+		logger.Info("keys", jaylog.Fields{
+			"AuthKey":    env.EncodedKey(64),
+			"EncryptKey": env.EncodedKey(32),
+			"CSRFKey":    env.EncodedKey(32),
+		})
 	case cEnvKeyUpdate.FullCommand():
 		err := env.UpdateFileKeys("env.json")
 		if err != nil {
-			app.Fatalf("%v", err)
+			fatalErr(err)
+		}
+		logger.Info("updated", jaylog.Fields{"file": "env.json"})
+	case cEnvConvert.FullCommand():
+		if *cEnvConvertTo != "yaml" {
+			logger.Fatal("unsupported_format", jaylog.Fields{"to": *cEnvConvertTo})
 		}
-		fmt.Println("Session keys updated in env.json.")
+
+		err := config.ConvertToYAML("env.json", "env.yaml")
+		if err != nil {
+			fatalErr(err)
+		}
+
+		logger.Info("created", jaylog.Fields{"file": "env.yaml", "source": "env.json"})
+		logger.Info("hint", jaylog.Fields{"env_var": "JAYCONFIG", "file": "env.yaml"})
 	}
 }
 
@@ -168,90 +252,273 @@ func commandMigrateMySQL(arg string, argList []string) {
 		return
 	}
 
-	var err error
-
-	// Config struct
 	info := &storage.Info{}
-	
-	configFile := ""
 
-	// Check if the config file path was passed
-	if len(*flagConfigFile) > 0 {
-		// Load the config from the passed file
-		err = jsonconfig.Load(*flagConfigFile, info)
-		// Get the config file path
-		configFile = *flagConfigFile
-	} else {
-		// Load the config from the environment variable
-		err = jsonconfig.LoadFromEnv(info)
-		// Get the config file path
-		configFile = os.Getenv("JAYCONFIG")
+	configFile, err := loadStorageConfig(info)
+	if err != nil {
+		fatalErr(err)
+	}
+
+	if err := validateMigrationConfig(configFile, info.MySQL.Database,
+		"Database name is missing from the config file.", &info.MySQL.Migration); err != nil {
+		fatalErr(err)
+	}
+
+	// Create a new configuration
+	mysqlConfig := &mysqlMigration.Configuration{
+		info.MySQL,
 	}
 
+	// Create a new migration object
+	mig, err := mysqlConfig.New()
 	if err != nil {
-		app.Fatalf("%v", err)
+		fatalErr(err)
 	}
 
-	// Perform config validation
-	if len(info.MySQL.Database) == 0 {
-		app.Fatalf("%v", "Database name is missing from the config file.")
+	switch arg {
+	case cMigrateMySQLStatus.FullCommand():
+		states, err := mig.StatusDetail()
+		if err != nil {
+			fatalErr(err)
+		}
+
+		printMigrationStatus(states, *cMigrateMySQLStatusFormat)
+	case cMigrateMySQLUp.FullCommand():
+		err = mig.UpSteps(*cMigrateMySQLUpSteps)
+		if err != nil {
+			fatalErr(err)
+		}
+		logMigrationResult(mig)
+	case cMigrateMySQLDown.FullCommand():
+		if len(*cMigrateMySQLDownTo) > 0 {
+			err = mig.To(*cMigrateMySQLDownTo)
+		} else {
+			err = mig.DownSteps(*cMigrateMySQLDownSteps)
+		}
+		if err != nil {
+			fatalErr(err)
+		}
+		logMigrationResult(mig)
+	case cMigrateMySQLRefresh.FullCommand():
+		if steps := *cMigrateMySQLRefreshSteps; steps > 0 {
+			err = mig.DownSteps(steps)
+			if err == nil {
+				err = mig.UpSteps(steps)
+			}
+		} else if mig.Position() == 0 {
+			err = mig.UpAll()
+		} else {
+			err = mig.DownAll()
+			if err == nil {
+				err = mig.UpAll()
+			}
+		}
+		if err != nil {
+			fatalErr(err)
+		}
+		logMigrationResult(mig)
+	default:
+		runMigration(mig, migrationCommands{
+			make:    cMigrateMySQLMake.FullCommand(),
+			makeArg: *cMigrateMySQLMakeDesc,
+			all:     cMigrateMySQLAll.FullCommand(),
+			reset:   cMigrateMySQLReset.FullCommand(),
+		}, arg)
 	}
-	
-	if len(info.MySQL.Migration.Folder) == 0 {
-		app.Fatalf("%v", "Migration folder is missing from the config file.")
+}
+
+func commandMigratePostgres(arg string, argList []string) {
+	if argList[0] != "migrate:postgres" {
+		return
 	}
-	
-	// Set to the absolute path
-	info.MySQL.Migration.Folder = filepath.Join(filepath.Dir(configFile), info.MySQL.Migration.Folder)
 
-	if !file.Exists(info.MySQL.Migration.Folder) {
-		app.Fatalf("%v", "Migration folder is not found on disk.")
+	info := &storage.Info{}
+
+	configFile, err := loadStorageConfig(info)
+	if err != nil {
+		fatalErr(err)
 	}
 
-	if len(info.MySQL.Migration.Table) == 0 {
-		app.Fatalf("%v", "Migration table is missing from the config file.")
+	if err := validateMigrationConfig(configFile, info.Postgres.Database,
+		"Database name is missing from the config file.", &info.Postgres.Migration); err != nil {
+		fatalErr(err)
 	}
 
 	// Create a new configuration
-	mysqlConfig := &mysqlMigration.Configuration{
-		info.MySQL,
+	postgresConfig := &postgresMigration.Configuration{
+		info.Postgres,
 	}
 
 	// Create a new migration object
-	mig, err := mysqlConfig.New()
+	mig, err := postgresConfig.New()
+	if err != nil {
+		fatalErr(err)
+	}
+
+	runMigration(mig, migrationCommands{
+		make:    cMigratePostgresMake.FullCommand(),
+		makeArg: *cMigratePostgresMakeDesc,
+		all:     cMigratePostgresAll.FullCommand(),
+		reset:   cMigratePostgresReset.FullCommand(),
+		refresh: cMigratePostgresRefresh.FullCommand(),
+		status:  cMigratePostgresStatus.FullCommand(),
+		up:      cMigratePostgresUp.FullCommand(),
+		down:    cMigratePostgresDown.FullCommand(),
+	}, arg)
+}
+
+func commandMigrateSQLite(arg string, argList []string) {
+	if argList[0] != "migrate:sqlite" {
+		return
+	}
+
+	info := &storage.Info{}
+
+	configFile, err := loadStorageConfig(info)
+	if err != nil {
+		fatalErr(err)
+	}
+
+	if err := validateMigrationConfig(configFile, info.SQLite.Path,
+		"Database path is missing from the config file.", &info.SQLite.Migration); err != nil {
+		fatalErr(err)
+	}
+
+	// Create a new configuration
+	sqliteConfig := &sqliteMigration.Configuration{
+		info.SQLite,
+	}
+
+	// Create a new migration object
+	mig, err := sqliteConfig.New()
 	if err != nil {
-		app.Fatalf("%v", err)
+		fatalErr(err)
 	}
 
+	runMigration(mig, migrationCommands{
+		make:    cMigrateSQLiteMake.FullCommand(),
+		makeArg: *cMigrateSQLiteMakeDesc,
+		all:     cMigrateSQLiteAll.FullCommand(),
+		reset:   cMigrateSQLiteReset.FullCommand(),
+		refresh: cMigrateSQLiteRefresh.FullCommand(),
+		status:  cMigrateSQLiteStatus.FullCommand(),
+		up:      cMigrateSQLiteUp.FullCommand(),
+		down:    cMigrateSQLiteDown.FullCommand(),
+	}, arg)
+}
+
+// loadStorageConfig loads the config file into info, using the file passed
+// via --config when set and falling back to the file referenced by
+// JAYCONFIG otherwise, and returns the path of the file that was loaded.
+func loadStorageConfig(info interface{}) (string, error) {
+	if len(*flagConfigFile) > 0 {
+		return *flagConfigFile, config.Load(*flagConfigFile, info)
+	}
+
+	return os.Getenv("JAYCONFIG"), config.LoadFromEnv(info)
+}
+
+// validateMigrationConfig checks that databaseValue (the driver's
+// connection setting, e.g. Database or Path) and mig's Folder/Table are
+// present, absolutizes Folder relative to configFile, and confirms Folder
+// exists on disk. databaseMissing is the message reported when
+// databaseValue is empty, since MySQL/Postgres and SQLite phrase it
+// differently ("Database name" vs. "Database path").
+func validateMigrationConfig(configFile, databaseValue, databaseMissing string, mig *storage.Migration) error {
+	if len(databaseValue) == 0 {
+		return fmt.Errorf(databaseMissing)
+	}
+
+	if len(mig.Folder) == 0 {
+		return fmt.Errorf("Migration folder is missing from the config file.")
+	}
+
+	// Set to the absolute path
+	mig.Folder = filepath.Join(filepath.Dir(configFile), mig.Folder)
+
+	if !file.Exists(mig.Folder) {
+		return fmt.Errorf("Migration folder is not found on disk.")
+	}
+
+	if len(mig.Table) == 0 {
+		return fmt.Errorf("Migration table is missing from the config file.")
+	}
+
+	return nil
+}
+
+// migrationCommands holds the full command strings for a single
+// migrate:<driver> command tree so runMigration can dispatch against any
+// storage.MigrationDriver the same way.
+type migrationCommands struct {
+	make    string
+	makeArg string
+	all     string
+	reset   string
+	refresh string
+	status  string
+	up      string
+	down    string
+}
+
+// runMigration dispatches a parsed migrate:<driver> command against the
+// given driver and prints the result, matching the behavior every
+// commandMigrate* function used to duplicate individually.
+func runMigration(mig storage.MigrationDriver, cmd migrationCommands, arg string) {
+	var err error
+
 	switch arg {
-	case cMigrateMySQLMake.FullCommand():
-		err = mig.Create(*cMigrateMySQLMakeDesc)
-	case cMigrateMySQLAll.FullCommand():
+	case cmd.make:
+		err = mig.Create(cmd.makeArg)
+	case cmd.all:
 		err = mig.UpAll()
-	case cMigrateMySQLReset.FullCommand():
+	case cmd.reset:
 		err = mig.DownAll()
-	case cMigrateMySQLRefresh.FullCommand():
+	case cmd.refresh:
 		if mig.Position() == 0 {
 			err = mig.UpAll()
 		} else {
 			err = mig.DownAll()
-			err = mig.UpAll()
+			if err == nil {
+				err = mig.UpAll()
+			}
 		}
-	case cMigrateMySQLStatus.FullCommand():
-		fmt.Println("Last migration:", mig.Status())
-	case cMigrateMySQLUp.FullCommand():
+	case cmd.status:
+		logger.Info("status", jaylog.Fields{"migration": mig.Status()})
+		return
+	case cmd.up:
 		err = mig.UpOne()
-	case cMigrateMySQLDown.FullCommand():
+	case cmd.down:
 		err = mig.DownOne()
 	}
 
 	if err != nil {
-		app.Fatalf("%v", err)
+		fatalErr(err)
 	} else {
-		fmt.Print(mig.Output())
+		logMigrationResult(mig)
 	}
 }
 
+// printMigrationStatus renders the migration states either as a
+// [Migration | Applied] table or as a JSON array, depending on format.
+func printMigrationStatus(states []mysqlMigration.State, format string) {
+	if format == "json" {
+		out, err := json.Marshal(states)
+		if err != nil {
+			fatalErr(err)
+		}
+		fmt.Println(string(out))
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "Migration\tApplied")
+	for _, s := range states {
+		fmt.Fprintf(w, "%v\t%v\n", s.Migration, s.Applied)
+	}
+	w.Flush()
+}
+
 func commandGenerate(arg string, args []string) {
 	if args[0] != "generate" {
 		return
@@ -267,17 +534,17 @@ func commandGenerate(arg string, args []string) {
 	// Check if the config file path was passed
 	if len(*flagConfigFile) > 0 {
 		// Load the config from the passed file
-		err = jsonconfig.Load(*flagConfigFile, info)
+		err = config.Load(*flagConfigFile, info)
 		configFile = *flagConfigFile
 	} else {
 		// Load the config from the environment variable
-		err = jsonconfig.LoadFromEnv(info)
+		err = config.LoadFromEnv(info)
 		// Get the config file path
 		configFile = os.Getenv("JAYCONFIG")
 	}
 
 	if err != nil {
-		app.Fatalf("%v", err)
+		fatalErr(err)
 	}
 
 	// Get the folders
@@ -287,7 +554,62 @@ func commandGenerate(arg string, args []string) {
 	// Generate the code
 	err = generate.Run(args[1:], projectFolder, templateFolder)
 	if err != nil {
-		app.Fatalf("%v", err)
+		fatalErr(err)
+	}
+}
+
+func commandGenerateAPI(arg string, args []string) {
+	if args[0] != "api" {
+		return
+	}
+
+	var err error
+
+	// Load the config
+	info := &generate.Container{}
+
+	configFile := ""
+
+	// Check if the config file path was passed
+	if len(*flagConfigFile) > 0 {
+		// Load the config from the passed file
+		err = config.Load(*flagConfigFile, info)
+		configFile = *flagConfigFile
+	} else {
+		// Load the config from the environment variable
+		err = config.LoadFromEnv(info)
+		// Get the config file path
+		configFile = os.Getenv("JAYCONFIG")
+	}
+
+	if err != nil {
+		fatalErr(err)
+	}
+
+	// Get the folders
+	projectFolder := filepath.Dir(configFile)
+	templateFolder := filepath.Join(projectFolder, info.Generation.TemplateFolder)
+
+	// Without a connection string, generate an example CRUD resource from
+	// the bundled template pair instead of introspecting a database.
+	if len(*cGenerateAPIConn) == 0 {
+		err = generate.Run([]string{"api/example"}, projectFolder, templateFolder)
+		if err != nil {
+			fatalErr(err)
+		}
+		return
+	}
+
+	tables := []string{}
+	if len(*cGenerateAPITables) > 0 {
+		tables = strings.Split(*cGenerateAPITables, ",")
+	}
+
+	// Introspect the database and generate a model, controller, and routes
+	// per table.
+	err = generate.API(*cGenerateAPIDriver, *cGenerateAPIConn, tables, projectFolder, templateFolder)
+	if err != nil {
+		fatalErr(err)
 	}
 }
 